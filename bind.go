@@ -0,0 +1,69 @@
+package zen
+
+import "net/http"
+
+// maxBodyBytes caps the size of a request body read by BindJSON, BindXML,
+// and BindASN1, via http.MaxBytesReader. Zero means unlimited.
+var maxBodyBytes int64
+
+// SetMaxBodyBytes sets the global body-size limit enforced by
+// BindJSON/BindXML/BindASN1 (and their ShouldBind* variants). Pass 0 to
+// disable the limit.
+func SetMaxBodyBytes(n int64) {
+	maxBodyBytes = n
+}
+
+func (c *Context) limitBody() {
+	if maxBodyBytes > 0 && c.req.Body != nil {
+		c.req.Body = http.MaxBytesReader(c.rw, c.req.Body, maxBodyBytes)
+	}
+}
+
+// BindJSON reads and validates a JSON request body into obj, writing a 400
+// response automatically if binding fails.
+func (c *Context) BindJSON(obj interface{}) error {
+	return c.bind(JSONBinding, obj)
+}
+
+// BindXML reads and validates an XML request body into obj, writing a 400
+// response automatically if binding fails.
+func (c *Context) BindXML(obj interface{}) error {
+	return c.bind(XMLBinding, obj)
+}
+
+// BindASN1 reads and validates an ASN.1 DER request body into obj, writing
+// a 400 response automatically if binding fails.
+func (c *Context) BindASN1(obj interface{}) error {
+	return c.bind(ASN1Binding, obj)
+}
+
+func (c *Context) bind(b Binding, obj interface{}) error {
+	if err := c.shouldBind(b, obj); err != nil {
+		c.Status(http.StatusBadRequest)
+		return err
+	}
+	return nil
+}
+
+// ShouldBindJSON reads and validates a JSON request body into obj. Unlike
+// BindJSON, it leaves writing an error response to the caller.
+func (c *Context) ShouldBindJSON(obj interface{}) error {
+	return c.shouldBind(JSONBinding, obj)
+}
+
+// ShouldBindXML reads and validates an XML request body into obj. Unlike
+// BindXML, it leaves writing an error response to the caller.
+func (c *Context) ShouldBindXML(obj interface{}) error {
+	return c.shouldBind(XMLBinding, obj)
+}
+
+// ShouldBindASN1 reads and validates an ASN.1 DER request body into obj.
+// Unlike BindASN1, it leaves writing an error response to the caller.
+func (c *Context) ShouldBindASN1(obj interface{}) error {
+	return c.shouldBind(ASN1Binding, obj)
+}
+
+func (c *Context) shouldBind(b Binding, obj interface{}) error {
+	c.limitBody()
+	return b.Bind(c.req, obj)
+}
@@ -0,0 +1,56 @@
+package zen
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type nestedAddress struct {
+	City string `json:"city" binding:"required"`
+}
+
+type nestedPerson struct {
+	Name    string        `json:"name" binding:"required"`
+	Address nestedAddress `json:"address"`
+}
+
+func TestBindJSONValidatesNestedStruct(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"a","address":{"city":""}}`))
+	req.Header.Set(contentType, applicationJSON)
+	rec := httptest.NewRecorder()
+
+	c := &Context{req: req, rw: rec}
+
+	var out nestedPerson
+	err := c.ShouldBindJSON(&out)
+	if err == nil {
+		t.Fatal("expected a BindError for the empty nested city field")
+	}
+
+	var bindErr *BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("expected *BindError, got %T", err)
+	}
+	if len(bindErr.Errors) != 1 || bindErr.Errors[0].Field != "City" {
+		t.Fatalf("Errors = %+v, want a single failure on City", bindErr.Errors)
+	}
+}
+
+func TestBindJSONRejectsBodyOverMaxBodyBytes(t *testing.T) {
+	SetMaxBodyBytes(10)
+	defer SetMaxBodyBytes(0)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"a very long name"}`))
+	req.Header.Set(contentType, applicationJSON)
+	rec := httptest.NewRecorder()
+
+	c := &Context{req: req, rw: rec}
+
+	var out nestedPerson
+	if err := c.ShouldBindJSON(&out); err == nil {
+		t.Fatal("expected an error for a body over the configured MaxBodyBytes limit")
+	}
+}
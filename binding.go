@@ -0,0 +1,460 @@
+package zen
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const bindTagName = "binding"
+
+// Binding describes a strategy for decoding a request body or form into a
+// Go value.
+type Binding interface {
+	Name() string
+	Bind(req *http.Request, obj interface{}) error
+}
+
+var (
+	// Form binds application/x-www-form-urlencoded requests using the
+	// existing form-scanning machinery.
+	Form Binding = formBinding{}
+	// JSONBinding binds application/json request bodies.
+	JSONBinding Binding = jsonBinding{}
+	// XMLBinding binds application/xml and text/xml request bodies.
+	XMLBinding Binding = xmlBinding{}
+	// MultipartForm binds multipart/form-data requests, including file
+	// uploads into *multipart.FileHeader fields.
+	MultipartForm Binding = multipartFormBinding{}
+	// ASN1Binding binds application/asn1 request bodies.
+	ASN1Binding Binding = asn1Binding{}
+)
+
+// BindError collects every field-level validation failure produced while
+// binding a request, instead of aborting on the first one.
+type BindError struct {
+	Errors []FieldError
+}
+
+// FieldError describes a single field that failed validation.
+type FieldError struct {
+	Field string
+	Rule  string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *BindError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// validatorFunc validates a single field's string representation against a
+// rule parameter, returning an error describing the failure.
+type validatorFunc func(fieldValue, param string) error
+
+var validatorRegistry = map[string]validatorFunc{
+	"required": validateRequired,
+	"min":      validateMin,
+	"max":      validateMax,
+	"email":    validateEmail,
+	"regexp":   validateRegexp,
+}
+
+// RegisterValidator registers a named rule usable in `binding` struct tags.
+// Registering a name that already exists overwrites the previous rule.
+func RegisterValidator(name string, fn func(fieldValue, param string) error) {
+	validatorRegistry[name] = fn
+}
+
+func validateRequired(fieldValue, _ string) error {
+	if fieldValue == "" {
+		return errors.New("is required")
+	}
+	return nil
+}
+
+func validateMin(fieldValue, param string) error {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return err
+	}
+	if len(fieldValue) < n {
+		return fmt.Errorf("must be at least %d characters", n)
+	}
+	return nil
+}
+
+func validateMax(fieldValue, param string) error {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return err
+	}
+	if len(fieldValue) > n {
+		return fmt.Errorf("must be at most %d characters", n)
+	}
+	return nil
+}
+
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+func validateEmail(fieldValue, _ string) error {
+	if !emailPattern.MatchString(fieldValue) {
+		return errors.New("must be a valid email address")
+	}
+	return nil
+}
+
+func validateRegexp(fieldValue, param string) error {
+	rxp, err := regexp.Compile(param)
+	if err != nil {
+		return err
+	}
+	if !rxp.MatchString(fieldValue) {
+		return fmt.Errorf("must match %s", param)
+	}
+	return nil
+}
+
+// runRules parses a comma-separated `binding` tag (e.g.
+// "required,min=3,max=64,email,regexp=^foo") and runs the rules in order
+// against fieldValue, stopping at and returning the first failure.
+func runRules(fieldName, fieldValue, rules string) error {
+	if rules == "" {
+		return nil
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		name, param := rule, ""
+		if idx := strings.IndexByte(rule, '='); idx >= 0 {
+			name, param = rule[:idx], rule[idx+1:]
+		}
+		fn, ok := validatorRegistry[name]
+		if !ok {
+			return fmt.Errorf("binding: unknown validator %q", name)
+		}
+		if err := fn(fieldValue, param); err != nil {
+			return &FieldError{Field: fieldName, Rule: rule, Err: err}
+		}
+	}
+	return nil
+}
+
+// scanStruct walks input's fields, validating and scanning each one via
+// get, collecting every failure into a BindError rather than stopping at
+// the first. Nested structs and pointer fields are scanned recursively;
+// slice fields are populated from every value get returns for their name.
+func scanStruct(input interface{}, get func(name string) ([]string, bool)) error {
+	inputValue := reflect.ValueOf(input).Elem()
+	inputType := inputValue.Type()
+
+	bindErr := &BindError{}
+
+	for i := 0; i < inputValue.NumField(); i++ {
+		field := inputValue.Field(i)
+		fieldType := inputType.Field(i)
+		tag := fieldType.Tag
+
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+
+		if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(multipart.FileHeader{}) {
+			if err := scanStruct(field.Addr().Interface(), get); err != nil {
+				var nested *BindError
+				if errors.As(err, &nested) {
+					bindErr.Errors = append(bindErr.Errors, nested.Errors...)
+					continue
+				}
+				return err
+			}
+			continue
+		}
+
+		name := fieldType.Tag.Get(inputTagName)
+		if name == "" {
+			name = fieldType.Name
+		}
+		rules := tag.Get(bindTagName)
+		values, _ := get(name)
+
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+			if err := runRules(name, strings.Join(values, ","), rules); err != nil {
+				var fe *FieldError
+				if errors.As(err, &fe) {
+					bindErr.Errors = append(bindErr.Errors, *fe)
+					continue
+				}
+				return err
+			}
+			if err := scanSlice(field, values); err != nil {
+				bindErr.Errors = append(bindErr.Errors, FieldError{Field: name, Err: err})
+			}
+			continue
+		}
+
+		var value string
+		if len(values) > 0 {
+			value = values[0]
+		}
+
+		if err := runRules(name, value, rules); err != nil {
+			var fe *FieldError
+			if errors.As(err, &fe) {
+				bindErr.Errors = append(bindErr.Errors, *fe)
+				continue
+			}
+			return err
+		}
+
+		if err := scan(field, value); err != nil {
+			bindErr.Errors = append(bindErr.Errors, FieldError{Field: name, Err: err})
+		}
+	}
+
+	if len(bindErr.Errors) > 0 {
+		return bindErr
+	}
+	return nil
+}
+
+// scanSlice populates v, a slice field, with one element per entry in
+// values, converting each the same way scan does for scalar fields.
+func scanSlice(v reflect.Value, values []string) error {
+	if !v.CanSet() {
+		return nil
+	}
+	slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+	for i, s := range values {
+		if err := scan(slice.Index(i), s); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+type formBinding struct{}
+
+func (formBinding) Name() string { return "form" }
+
+func (formBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := req.ParseForm(); err != nil {
+		return err
+	}
+	return scanStruct(obj, func(name string) ([]string, bool) {
+		values, ok := req.Form[name]
+		return values, ok
+	})
+}
+
+// defaultMaxMemory matches net/http's own default for ParseMultipartForm.
+const defaultMaxMemory = 32 << 20
+
+type multipartFormBinding struct{}
+
+func (multipartFormBinding) Name() string { return "multipart/form-data" }
+
+func (multipartFormBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := req.ParseMultipartForm(defaultMaxMemory); err != nil {
+		return err
+	}
+
+	if err := scanStruct(obj, func(name string) ([]string, bool) {
+		values, ok := req.MultipartForm.Value[name]
+		return values, ok
+	}); err != nil {
+		return err
+	}
+
+	return bindMultipartFiles(obj, req.MultipartForm)
+}
+
+// bindMultipartFiles fills any *multipart.FileHeader fields whose `form`
+// tag matches an uploaded file part.
+func bindMultipartFiles(input interface{}, form *multipart.Form) error {
+	inputValue := reflect.ValueOf(input).Elem()
+	inputType := inputValue.Type()
+
+	for i := 0; i < inputValue.NumField(); i++ {
+		field := inputValue.Field(i)
+		if field.Type() != reflect.TypeOf(&multipart.FileHeader{}) {
+			continue
+		}
+		name := inputType.Field(i).Tag.Get(inputTagName)
+		if name == "" {
+			name = inputType.Field(i).Name
+		}
+		files := form.File[name]
+		if len(files) == 0 {
+			continue
+		}
+		field.Set(reflect.ValueOf(files[0]))
+	}
+	return nil
+}
+
+type jsonBinding struct{}
+
+func (jsonBinding) Name() string { return "json" }
+
+func (jsonBinding) Bind(req *http.Request, obj interface{}) error {
+	if req.Body == nil {
+		return errors.New("binding: nil request body")
+	}
+	return decodeJSON(req.Body, obj)
+}
+
+func decodeJSON(r io.Reader, obj interface{}) error {
+	dec := json.NewDecoder(r)
+	if disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(obj); err != nil {
+		return err
+	}
+	return validateTagged(obj)
+}
+
+type xmlBinding struct{}
+
+func (xmlBinding) Name() string { return "xml" }
+
+func (xmlBinding) Bind(req *http.Request, obj interface{}) error {
+	if req.Body == nil {
+		return errors.New("binding: nil request body")
+	}
+	if err := xml.NewDecoder(req.Body).Decode(obj); err != nil {
+		return err
+	}
+	return validateTagged(obj)
+}
+
+type asn1Binding struct{}
+
+func (asn1Binding) Name() string { return "asn1" }
+
+func (asn1Binding) Bind(req *http.Request, obj interface{}) error {
+	if req.Body == nil {
+		return errors.New("binding: nil request body")
+	}
+	bts, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	if _, err := asn1.Unmarshal(bts, obj); err != nil {
+		return err
+	}
+	return validateTagged(obj)
+}
+
+// validateTagged runs `binding` tag rules over an already-decoded struct,
+// as used after JSON/XML/ASN1 bodies are unmarshaled directly (no
+// form-scanning involved). Nested struct fields are validated recursively,
+// mirroring scanStruct.
+func validateTagged(obj interface{}) error {
+	return validateTaggedValue(reflect.ValueOf(obj).Elem())
+}
+
+func validateTaggedValue(inputValue reflect.Value) error {
+	inputType := inputValue.Type()
+
+	bindErr := &BindError{}
+	for i := 0; i < inputValue.NumField(); i++ {
+		fieldType := inputType.Field(i)
+		if fieldType.PkgPath != "" {
+			continue // unexported field
+		}
+
+		field := inputValue.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+
+		if field.Kind() == reflect.Struct {
+			if err := validateTaggedValue(field); err != nil {
+				var nested *BindError
+				if errors.As(err, &nested) {
+					bindErr.Errors = append(bindErr.Errors, nested.Errors...)
+					continue
+				}
+				return err
+			}
+			continue
+		}
+
+		rules := fieldType.Tag.Get(bindTagName)
+		if rules == "" {
+			continue
+		}
+		name := fieldType.Tag.Get(inputTagName)
+		if name == "" {
+			name = fieldType.Name
+		}
+		value := fmt.Sprintf("%v", field.Interface())
+		if err := runRules(name, value, rules); err != nil {
+			var fe *FieldError
+			if errors.As(err, &fe) {
+				bindErr.Errors = append(bindErr.Errors, *fe)
+				continue
+			}
+			return err
+		}
+	}
+	if len(bindErr.Errors) > 0 {
+		return bindErr
+	}
+	return nil
+}
+
+// disallowUnknownFields toggles json.Decoder.DisallowUnknownFields for every
+// JSON bind performed through zen. Off by default to match encoding/json.
+var disallowUnknownFields = false
+
+// DisallowUnknownFields enables or disables strict JSON decoding globally.
+func DisallowUnknownFields(disallow bool) {
+	disallowUnknownFields = disallow
+}
+
+// bindingFor picks a Binding based on the request's Content-Type header.
+func bindingFor(req *http.Request) Binding {
+	ct := req.Header.Get(contentType)
+	switch {
+	case strings.Contains(ct, applicationJSON):
+		return JSONBinding
+	case strings.Contains(ct, applicationXML), strings.Contains(ct, textXML):
+		return XMLBinding
+	case strings.Contains(ct, "multipart/form-data"):
+		return MultipartForm
+	case strings.Contains(ct, applicationASN1):
+		return ASN1Binding
+	default:
+		return Form
+	}
+}
+
+// Bind dispatches to the Binding matching the request's Content-Type header
+// and binds the request into i.
+func (c *Context) Bind(i interface{}) error {
+	return bindingFor(c.req).Bind(c.req, i)
+}
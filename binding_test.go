@@ -0,0 +1,253 @@
+package zen
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type sliceForm struct {
+	Tags []string `form:"tags" binding:"required"`
+	Nums []int    `form:"nums"`
+}
+
+func TestFormBindingScansSlices(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader("tags=a&tags=b&tags=c&nums=1&nums=2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(contentType, "application/x-www-form-urlencoded")
+
+	var out sliceForm
+	if err := Form.Bind(req, &out); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+
+	if want := []string{"a", "b", "c"}; !equalStrings(out.Tags, want) {
+		t.Errorf("Tags = %v, want %v", out.Tags, want)
+	}
+	if want := []int{1, 2}; !equalInts(out.Nums, want) {
+		t.Errorf("Nums = %v, want %v", out.Nums, want)
+	}
+}
+
+func TestFormBindingRequiredSliceMissing(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{}.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(contentType, "application/x-www-form-urlencoded")
+
+	var out sliceForm
+	err = Form.Bind(req, &out)
+	if err == nil {
+		t.Fatal("expected a BindError for missing required slice")
+	}
+	var bindErr *BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("expected *BindError, got %T", err)
+	}
+}
+
+func TestRunRulesRequired(t *testing.T) {
+	if err := runRules("Name", "", "required"); err == nil {
+		t.Fatal("expected an error for an empty required field")
+	}
+	if err := runRules("Name", "x", "required"); err != nil {
+		t.Fatalf("runRules: %v", err)
+	}
+}
+
+func TestRunRulesMinMax(t *testing.T) {
+	if err := runRules("Name", "ab", "min=3"); err == nil {
+		t.Fatal("expected an error for a value shorter than min")
+	}
+	if err := runRules("Name", "abc", "min=3"); err != nil {
+		t.Fatalf("runRules: %v", err)
+	}
+	if err := runRules("Name", "abcd", "max=3"); err == nil {
+		t.Fatal("expected an error for a value longer than max")
+	}
+	if err := runRules("Name", "abc", "max=3"); err != nil {
+		t.Fatalf("runRules: %v", err)
+	}
+}
+
+func TestRunRulesEmail(t *testing.T) {
+	if err := runRules("Email", "not-an-email", "email"); err == nil {
+		t.Fatal("expected an error for an invalid email address")
+	}
+	if err := runRules("Email", "a@b.com", "email"); err != nil {
+		t.Fatalf("runRules: %v", err)
+	}
+}
+
+func TestRunRulesRegexp(t *testing.T) {
+	if err := runRules("Code", "abc", `regexp=^\d+$`); err == nil {
+		t.Fatal("expected an error for a non-matching value")
+	}
+	if err := runRules("Code", "123", `regexp=^\d+$`); err != nil {
+		t.Fatalf("runRules: %v", err)
+	}
+}
+
+func TestRunRulesUnknownValidator(t *testing.T) {
+	if err := runRules("Name", "x", "nope"); err == nil {
+		t.Fatal("expected an error for an unknown validator name")
+	}
+}
+
+func TestRunRulesChainStopsAtFirstFailure(t *testing.T) {
+	err := runRules("Name", "", "required,min=3")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("expected *FieldError, got %T", err)
+	}
+	if fe.Rule != "required" {
+		t.Fatalf("Rule = %q, want %q (should stop at the first failing rule)", fe.Rule, "required")
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	RegisterValidator("even", func(fieldValue, _ string) error {
+		n, err := strconv.Atoi(fieldValue)
+		if err != nil {
+			return err
+		}
+		if n%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+	defer delete(validatorRegistry, "even")
+
+	if err := runRules("N", "3", "even"); err == nil {
+		t.Fatal("expected an error for an odd value")
+	}
+	if err := runRules("N", "4", "even"); err != nil {
+		t.Fatalf("runRules: %v", err)
+	}
+}
+
+type validatedForm struct {
+	Name  string `form:"name" binding:"required,min=2,max=10"`
+	Email string `form:"email" binding:"required,email"`
+}
+
+func TestFormBindingRunsValidators(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader("name=a&email=not-an-email"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(contentType, "application/x-www-form-urlencoded")
+
+	var out validatedForm
+	err = Form.Bind(req, &out)
+	if err == nil {
+		t.Fatal("expected a BindError")
+	}
+	var bindErr *BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("expected *BindError, got %T", err)
+	}
+	if len(bindErr.Errors) != 2 {
+		t.Fatalf("Errors = %+v, want failures on both Name (min) and Email", bindErr.Errors)
+	}
+}
+
+type uploadForm struct {
+	Title string                `form:"title" binding:"required"`
+	File  *multipart.FileHeader `form:"file"`
+}
+
+func TestMultipartFormBindingBindsFile(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.WriteField("title", "report"); err != nil {
+		t.Fatal(err)
+	}
+	part, err := mw.CreateFormFile("file", "report.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write([]byte("contents")); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/", &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(contentType, mw.FormDataContentType())
+
+	var out uploadForm
+	if err := MultipartForm.Bind(req, &out); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if out.Title != "report" {
+		t.Errorf("Title = %q, want %q", out.Title, "report")
+	}
+	if out.File == nil || out.File.Filename != "report.txt" {
+		t.Fatalf("File = %+v, want a header for report.txt", out.File)
+	}
+}
+
+func TestBindingForDispatchesByContentType(t *testing.T) {
+	cases := []struct {
+		contentTypeHeader string
+		want              Binding
+	}{
+		{applicationJSON, JSONBinding},
+		{applicationXML, XMLBinding},
+		{textXML, XMLBinding},
+		{"multipart/form-data; boundary=x", MultipartForm},
+		{applicationASN1, ASN1Binding},
+		{"application/x-www-form-urlencoded", Form},
+	}
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodPost, "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(contentType, tc.contentTypeHeader)
+		if got := bindingFor(req); got != tc.want {
+			t.Errorf("bindingFor(%q) = %q, want %q", tc.contentTypeHeader, got.Name(), tc.want.Name())
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
@@ -0,0 +1,197 @@
+package zen
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// minCompressSize is the smallest response body, in bytes, worth
+// compressing. Anything smaller is written through untouched to avoid
+// inflating tiny payloads with encoding overhead. The size is judged from
+// the first Write alone: a body whose first Write is smaller than this is
+// passed through whole, whatever its total length.
+const minCompressSize = 1024
+
+// skipCompressPrefixes lists content types that are already compressed and
+// should not be re-encoded.
+var skipCompressPrefixes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+}
+
+// CompressingResponseWriter wraps an http.ResponseWriter and transparently
+// gzips or deflates the body according to the negotiated encoding, falling
+// back to passthrough for small or already-compressed bodies.
+type CompressingResponseWriter struct {
+	http.ResponseWriter
+	req *http.Request
+
+	encoding      string
+	gzipLevel     int
+	gzipLevelSet  bool
+	writer        io.WriteCloser
+	statusCode    int
+	wroteHeader   bool // WriteHeader was called by the handler
+	headerFlushed bool // the real ResponseWriter.WriteHeader was called
+	skip          bool
+}
+
+// newCompressingResponseWriter picks gzip or deflate based on the request's
+// Accept-Encoding header. It returns nil if the client accepts neither.
+func newCompressingResponseWriter(rw http.ResponseWriter, req *http.Request) *CompressingResponseWriter {
+	accept := req.Header.Get("Accept-Encoding")
+	var encoding string
+	switch {
+	case strings.Contains(accept, "gzip"):
+		encoding = "gzip"
+	case strings.Contains(accept, "deflate"):
+		encoding = "deflate"
+	default:
+		return nil
+	}
+	return &CompressingResponseWriter{ResponseWriter: rw, req: req, encoding: encoding}
+}
+
+// WriteHeader records the status code the handler asked for and decides,
+// from Content-Type, whether this response is already-compressed content
+// that should be skipped. It does NOT forward to the underlying
+// ResponseWriter yet: that decision also depends on body size, which isn't
+// known until the first Write, and Content-Encoding must be set before
+// any header reaches the client.
+func (w *CompressingResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.statusCode = code
+
+	ct := w.Header().Get(contentType)
+	for _, prefix := range skipCompressPrefixes {
+		if strings.HasPrefix(ct, prefix) {
+			w.skip = true
+		}
+	}
+}
+
+// flushHeader sets Content-Encoding (unless skipping) and forwards the
+// status code to the underlying ResponseWriter. Safe to call more than
+// once.
+func (w *CompressingResponseWriter) flushHeader() {
+	if w.headerFlushed {
+		return
+	}
+	w.headerFlushed = true
+
+	if !w.skip {
+		w.Header().Set("Content-Encoding", w.encoding)
+		w.Header().Del("Content-Length")
+	}
+
+	code := w.statusCode
+	if code == 0 {
+		code = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *CompressingResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.headerFlushed {
+		if len(p) < minCompressSize {
+			w.skip = true
+		}
+		w.flushHeader()
+
+		if !w.skip {
+			level := gzip.DefaultCompression
+			if w.gzipLevelSet {
+				level = w.gzipLevel
+			}
+
+			switch w.encoding {
+			case "gzip":
+				w.writer, _ = gzip.NewWriterLevel(w.ResponseWriter, level)
+			case "deflate":
+				w.writer, _ = flate.NewWriter(w.ResponseWriter, level)
+			}
+		}
+	}
+
+	if w.skip {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.writer.Write(p)
+}
+
+// Flush implements http.Flusher so streaming handlers (SSE, long-poll)
+// keep working once compression is installed.
+func (w *CompressingResponseWriter) Flush() {
+	if w.writer != nil {
+		if f, ok := w.writer.(interface{ Flush() error }); ok {
+			_ = f.Flush()
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker so websocket upgrades still work when
+// compression middleware is installed ahead of them.
+func (w *CompressingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return hj.Hijack()
+}
+
+func (w *CompressingResponseWriter) Close() error {
+	if !w.headerFlushed {
+		w.skip = true
+		w.flushHeader()
+	}
+	if w.writer != nil {
+		return w.writer.Close()
+	}
+	return nil
+}
+
+// EnableCompression swaps the Context's underlying ResponseWriter for one
+// that transparently gzips or deflates the body, based on the request's
+// Accept-Encoding header. It is a no-op if the client accepts neither.
+func (c *Context) EnableCompression() {
+	if crw := newCompressingResponseWriter(c.rw, c.req); crw != nil {
+		c.rw = crw
+	}
+}
+
+// Gzip returns middleware that enables transparent response compression
+// for every request, at the given compress/gzip level.
+func Gzip(level int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			crw := newCompressingResponseWriter(rw, req)
+			if crw == nil {
+				next.ServeHTTP(rw, req)
+				return
+			}
+			crw.gzipLevel = level
+			crw.gzipLevelSet = true
+			defer crw.Close()
+			next.ServeHTTP(crw, req)
+		})
+	}
+}
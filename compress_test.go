@@ -0,0 +1,122 @@
+package zen
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressingResponseWriterSetsContentEncodingBeforeBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	crw := newCompressingResponseWriter(rec, req)
+	if crw == nil {
+		t.Fatal("expected a compressing writer for gzip Accept-Encoding")
+	}
+
+	crw.Header().Set(contentType, applicationJSON)
+	crw.WriteHeader(http.StatusOK)
+	body := strings.Repeat("x", minCompressSize+1)
+	if _, err := crw.Write([]byte(body)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := crw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	res := rec.Result()
+	if got := res.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want %q", got, "gzip")
+	}
+
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer gz.Close()
+	out, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("reading gzip body: %v", err)
+	}
+	if string(out) != body {
+		t.Fatalf("decompressed body mismatch")
+	}
+}
+
+func TestCompressingResponseWriterSkipsSmallBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	crw := newCompressingResponseWriter(rec, req)
+	crw.Header().Set(contentType, applicationJSON)
+	crw.WriteHeader(http.StatusOK)
+	if _, err := crw.Write([]byte("tiny")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	res := rec.Result()
+	if got := res.Header.Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want empty for small body", got)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if string(body) != "tiny" {
+		t.Fatalf("body = %q, want %q", body, "tiny")
+	}
+}
+
+func TestGzipMiddlewareAllowsNoCompressionLevel(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	body := strings.Repeat("x", minCompressSize+1)
+	handler := Gzip(gzip.NoCompression)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set(contentType, applicationJSON)
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte(body))
+	}))
+	handler.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	if got := res.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want %q", got, "gzip")
+	}
+
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer gz.Close()
+	out, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("reading gzip body: %v", err)
+	}
+	if string(out) != body {
+		t.Fatalf("decompressed body mismatch")
+	}
+}
+
+func TestCompressingResponseWriterSkipsCompressedContentType(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	crw := newCompressingResponseWriter(rec, req)
+	crw.Header().Set(contentType, "image/png")
+	crw.WriteHeader(http.StatusOK)
+	body := strings.Repeat("x", minCompressSize+1)
+	if _, err := crw.Write([]byte(body)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	res := rec.Result()
+	if got := res.Header.Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want empty for image content", got)
+	}
+}
@@ -1,21 +1,13 @@
 package zen
 
 import (
-	"encoding/asn1"
-	"encoding/json"
-	"encoding/xml"
-	"errors"
 	"net/http"
-	"net/url"
 	"reflect"
-	"regexp"
 	"strconv"
 )
 
 const (
 	inputTagName = "form"
-	validTagName = "valid"
-	validMsgName = "msg"
 )
 
 //commonly used mime-types
@@ -36,37 +28,10 @@ type (
 	}
 )
 
-// ParseValidForm will parse request's form and map into a interface{} value
+// ParseValidForm will parse request's form and map into a interface{} value,
+// validating each field against its `binding` struct tag.
 func (c *Context) ParseValidForm(input interface{}) error {
-	if err := c.req.ParseForm(); err != nil {
-		return err
-	}
-	return parseValidForm(input, c.req.Form)
-}
-
-func parseValidForm(input interface{}, form url.Values) error {
-	inputValue := reflect.ValueOf(input).Elem()
-	inputType := inputValue.Type()
-
-	for i := 0; i < inputValue.NumField(); i++ {
-		tag := inputType.Field(i).Tag
-		formName := tag.Get(inputTagName)
-		validate := tag.Get(validTagName)
-		validateMsg := tag.Get(validMsgName)
-		field := inputValue.Field(i)
-		formValue := form.Get(formName)
-
-		// validate form with regex
-		if err := valid(formValue, validate, validateMsg); err != nil {
-			return err
-		}
-		// scan form string value into field
-		if err := scan(field, formValue); err != nil {
-			return err
-		}
-
-	}
-	return nil
+	return Form.Bind(c.req, input)
 }
 
 func scan(v reflect.Value, s string) error {
@@ -111,59 +76,19 @@ func scan(v reflect.Value, s string) error {
 	return nil
 }
 
-func valid(s string, validate, msg string) error {
-	if validate == "" {
-		return nil
-	}
-	rxp, err := regexp.Compile(validate)
-	if err != nil {
-		return err
-	}
-
-	if !rxp.MatchString(s) {
-		return errors.New(msg)
-	}
-
-	return nil
+// JSON : write json data to http response writer, with the given status code
+func (c *Context) JSON(code int, i interface{}) error {
+	return c.Render(code, JSONRender{Data: i})
 }
 
-// JSON : write json data to http response writer, with status code 200
-func (c *Context) JSON(i interface{}) (err error) {
-	// write http status code
-	c.Head(contentType, applicationJSON)
-
-	// Encode json data to rw
-	err = json.NewEncoder(c.rw).Encode(i)
-
-	//return
-	return
+// XML : write xml data to http response writer, with the given status code
+func (c *Context) XML(code int, i interface{}) error {
+	return c.Render(code, XMLRender{Data: i})
 }
 
-// XML : write xml data to http response writer, with status code 200
-func (c *Context) XML(i interface{}) (err error) {
-	// write http status code
-	c.Head(contentType, applicationXML)
-
-	// Encode xml data to rw
-	err = xml.NewEncoder(c.rw).Encode(i)
-
-	//return
-	return
-}
-
-// ASN1 : write asn1 data to http response writer, with status code 200
-func (c *Context) ASN1(i interface{}) (err error) {
-	// write http status code
-	c.Head(contentType, applicationASN1)
-
-	// Encode asn1 data to rw
-	bts, err := asn1.Marshal(i)
-	if err != nil {
-		return
-	}
-	//return
-	_, err = c.rw.Write(bts)
-	return
+// ASN1 : write asn1 data to http response writer, with the given status code
+func (c *Context) ASN1(code int, i interface{}) error {
+	return c.Render(code, ASN1Render{Data: i})
 }
 
 // Status set response's status code
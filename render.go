@@ -0,0 +1,238 @@
+package zen
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//commonly used mime-types for rendering
+const (
+	applicationYAML = "application/x-yaml"
+	textPlain       = "text/plain"
+	textHTML        = "text/html"
+)
+
+// Render is anything that can write itself to an http.ResponseWriter and
+// report the Content-Type it wrote.
+type Render interface {
+	Render(rw http.ResponseWriter) error
+	ContentType() string
+}
+
+// Render writes code as the response status, sets the Content-Type
+// reported by r, and delegates the body to r. JSON, XML, ASN1, YAML,
+// String, and HTML all go through this.
+func (c *Context) Render(code int, r Render) error {
+	c.Head(contentType, r.ContentType())
+	c.Status(code)
+	return r.Render(c.rw)
+}
+
+// JSONRender encodes Data as JSON.
+type JSONRender struct {
+	Data interface{}
+}
+
+func (r JSONRender) ContentType() string { return applicationJSON }
+
+func (r JSONRender) Render(rw http.ResponseWriter) error {
+	return json.NewEncoder(rw).Encode(r.Data)
+}
+
+// XMLRender encodes Data as XML.
+type XMLRender struct {
+	Data interface{}
+}
+
+func (r XMLRender) ContentType() string { return applicationXML }
+
+func (r XMLRender) Render(rw http.ResponseWriter) error {
+	return xml.NewEncoder(rw).Encode(r.Data)
+}
+
+// ASN1Render encodes Data as ASN.1 DER.
+type ASN1Render struct {
+	Data interface{}
+}
+
+func (r ASN1Render) ContentType() string { return applicationASN1 }
+
+func (r ASN1Render) Render(rw http.ResponseWriter) error {
+	bts, err := asn1.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	_, err = rw.Write(bts)
+	return err
+}
+
+// YAMLRender encodes Data as YAML.
+type YAMLRender struct {
+	Data interface{}
+}
+
+func (r YAMLRender) ContentType() string { return applicationYAML }
+
+func (r YAMLRender) Render(rw http.ResponseWriter) error {
+	return yaml.NewEncoder(rw).Encode(r.Data)
+}
+
+// StringRender writes a formatted plain-text string.
+type StringRender struct {
+	Format string
+	Data   []interface{}
+}
+
+func (r StringRender) ContentType() string { return textPlain }
+
+func (r StringRender) Render(rw http.ResponseWriter) error {
+	var err error
+	if len(r.Data) > 0 {
+		_, err = fmt.Fprintf(rw, r.Format, r.Data...)
+	} else {
+		_, err = io.WriteString(rw, r.Format)
+	}
+	return err
+}
+
+// HTMLRender executes a named html/template and writes the result.
+type HTMLRender struct {
+	Template *template.Template
+	Name     string
+	Data     interface{}
+}
+
+func (r HTMLRender) ContentType() string { return textHTML }
+
+func (r HTMLRender) Render(rw http.ResponseWriter) error {
+	if r.Name == "" {
+		return r.Template.Execute(rw, r.Data)
+	}
+	return r.Template.ExecuteTemplate(rw, r.Name, r.Data)
+}
+
+// YAML writes data to the response as YAML, with the given status code.
+func (c *Context) YAML(code int, data interface{}) error {
+	return c.Render(code, YAMLRender{Data: data})
+}
+
+// String writes a formatted plain-text response, with the given status
+// code.
+func (c *Context) String(code int, format string, data ...interface{}) error {
+	return c.Render(code, StringRender{Format: format, Data: data})
+}
+
+// HTML executes the named template against data and writes the result as
+// text/html, with the given status code.
+func (c *Context) HTML(code int, tmpl *template.Template, name string, data interface{}) error {
+	return c.Render(code, HTMLRender{Template: tmpl, Name: name, Data: data})
+}
+
+// negotiators maps a mime type to the Render it should produce.
+var negotiators = map[string]func(data interface{}) Render{
+	applicationJSON: func(data interface{}) Render { return JSONRender{Data: data} },
+	applicationXML:  func(data interface{}) Render { return XMLRender{Data: data} },
+	textXML:         func(data interface{}) Render { return XMLRender{Data: data} },
+	applicationYAML: func(data interface{}) Render { return YAMLRender{Data: data} },
+	textPlain:       func(data interface{}) Render { return StringRender{Format: fmt.Sprintf("%v", data)} },
+}
+
+// Negotiate picks the best of offered against the request's Accept header
+// (honoring q-values) and renders data through the matching Render. It
+// responds 406 Not Acceptable if none of the offered types are acceptable
+// to the client.
+func (c *Context) Negotiate(code int, offered []string, data interface{}) error {
+	mime := negotiateAccept(c.req.Header.Get("Accept"), offered)
+	if mime == "" {
+		c.Status(http.StatusNotAcceptable)
+		return errors.New("zen: none of the offered content types are acceptable")
+	}
+
+	newRender, ok := negotiators[mime]
+	if !ok {
+		c.Status(http.StatusNotAcceptable)
+		return fmt.Errorf("zen: no renderer registered for %q", mime)
+	}
+
+	return c.Render(code, newRender(data))
+}
+
+type acceptEntry struct {
+	mime string
+	q    float64
+}
+
+// negotiateAccept parses an Accept header with q-values and returns the
+// highest-priority entry that also appears in offered. A */* entry matches
+// the first offered type that the header does not reject with q=0, and an
+// empty header accepts offered[0]. It returns "" if none match.
+func negotiateAccept(accept string, offered []string) string {
+	if accept == "" {
+		if len(offered) > 0 {
+			return offered[0]
+		}
+		return ""
+	}
+
+	var entries []acceptEntry
+	for _, part := range strings.Split(accept, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mime, q := part, 1.0
+		if idx := strings.Index(part, ";"); idx >= 0 {
+			mime = strings.TrimSpace(part[:idx])
+			for _, param := range strings.Split(part[idx+1:], ";") {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					if f, err := strconv.ParseFloat(param[2:], 64); err == nil {
+						q = f
+					}
+				}
+			}
+		}
+		entries = append(entries, acceptEntry{mime: mime, q: q})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+
+	rejected := make(map[string]bool)
+	for _, e := range entries {
+		if e.q == 0 {
+			rejected[e.mime] = true
+		}
+	}
+
+	for _, e := range entries {
+		// RFC 7231 section 5.3.2: q=0 means "not acceptable", not just low priority.
+		if e.q == 0 {
+			continue
+		}
+		if e.mime == "*/*" {
+			for _, o := range offered {
+				if !rejected[o] {
+					return o
+				}
+			}
+			continue
+		}
+		for _, o := range offered {
+			if e.mime == o {
+				return o
+			}
+		}
+	}
+	return ""
+}
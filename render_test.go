@@ -0,0 +1,24 @@
+package zen
+
+import "testing"
+
+func TestNegotiateAcceptRejectsZeroQ(t *testing.T) {
+	got := negotiateAccept("application/json;q=0, text/plain;q=0.5", []string{applicationJSON, textPlain})
+	if got != textPlain {
+		t.Fatalf("negotiateAccept = %q, want %q (json has q=0 and must be excluded)", got, textPlain)
+	}
+}
+
+func TestNegotiateAcceptPicksHighestQ(t *testing.T) {
+	got := negotiateAccept("text/plain;q=0.5, application/json;q=0.9", []string{applicationJSON, textPlain})
+	if got != applicationJSON {
+		t.Fatalf("negotiateAccept = %q, want %q", got, applicationJSON)
+	}
+}
+
+func TestNegotiateAcceptAllZeroQReturnsEmpty(t *testing.T) {
+	got := negotiateAccept("application/json;q=0", []string{applicationJSON})
+	if got != "" {
+		t.Fatalf("negotiateAccept = %q, want empty when every offer is q=0", got)
+	}
+}
@@ -0,0 +1,105 @@
+package zen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+//commonly used mime-types for streaming/serialization responses
+const (
+	textEventStream     = "text/event-stream"
+	applicationProtobuf = "application/x-protobuf"
+	applicationMsgPack  = "application/x-msgpack"
+)
+
+// SSEvent writes a single Server-Sent Event to the response, formatted per
+// the text/event-stream spec, and flushes immediately so the client
+// receives it without buffering. id and event are optional: pass "" to
+// omit either line.
+func (c *Context) SSEvent(id, event string, data interface{}) error {
+	c.Head(contentType, textEventStream)
+	c.Head("Cache-Control", "no-cache")
+	c.Head("Connection", "keep-alive")
+
+	payload, ok := data.(string)
+	if !ok {
+		bts, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		payload = string(bts)
+	}
+
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	// Every line of the payload needs its own "data: " prefix, or
+	// EventSource drops any continuation line with no recognized field name.
+	for _, line := range strings.Split(payload, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteByte('\n')
+
+	if _, err := io.WriteString(c.rw, b.String()); err != nil {
+		return err
+	}
+
+	if f, ok := c.rw.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Stream repeatedly invokes step with the response writer until step
+// returns false or the client disconnects, flushing after every write.
+func (c *Context) Stream(step func(w io.Writer) bool) {
+	flusher, canFlush := c.rw.(http.Flusher)
+	done := c.req.Context().Done()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		if !step(c.rw) {
+			return
+		}
+		if canFlush {
+			flusher.Flush()
+		}
+	}
+}
+
+// Protobuf writes a protobuf-encoded message to the response, with status
+// code 200.
+func (c *Context) Protobuf(pb proto.Message) (err error) {
+	c.Head(contentType, applicationProtobuf)
+
+	bts, err := proto.Marshal(pb)
+	if err != nil {
+		return
+	}
+	_, err = c.rw.Write(bts)
+	return
+}
+
+// MsgPack writes a MessagePack-encoded value to the response, with status
+// code 200.
+func (c *Context) MsgPack(i interface{}) (err error) {
+	c.Head(contentType, applicationMsgPack)
+
+	err = msgpack.NewEncoder(c.rw).Encode(i)
+	return
+}
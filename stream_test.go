@@ -0,0 +1,72 @@
+package zen
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSSEventFormatsIDEventData(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := &Context{req: req, rw: rec}
+
+	if err := c.SSEvent("42", "tick", "hello"); err != nil {
+		t.Fatalf("SSEvent: %v", err)
+	}
+
+	want := "id: 42\nevent: tick\ndata: hello\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestSSEventOmitsEmptyIDAndEvent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := &Context{req: req, rw: rec}
+
+	if err := c.SSEvent("", "", "hello"); err != nil {
+		t.Fatalf("SSEvent: %v", err)
+	}
+
+	want := "data: hello\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestSSEventPrefixesEveryLineOfMultilineData(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := &Context{req: req, rw: rec}
+
+	if err := c.SSEvent("", "", "line1\nline2"); err != nil {
+		t.Fatalf("SSEvent: %v", err)
+	}
+
+	want := "data: line1\ndata: line2\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestSSEventJSONEncodesNonStringData(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := &Context{req: req, rw: rec}
+
+	type payload struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	if err := c.SSEvent("", "", payload{ID: 1, Name: "x"}); err != nil {
+		t.Fatalf("SSEvent: %v", err)
+	}
+
+	want := `data: {"id":1,"name":"x"}` + "\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}